@@ -0,0 +1,25 @@
+// Package changelog holds the data types shared between the Panthor API
+// client and the notification providers.
+package changelog
+
+// Response is the envelope returned by the Panthor changelog API.
+type Response struct {
+	Data        []Changelog `json:"data"`
+	RequestedAt int         `json:"requested_at"`
+}
+
+// Changelog describes a single released version of the Panthor server.
+type Changelog struct {
+	ID            int      `json:"id"`
+	Version       string   `json:"version"`
+	ChangeMission []string `json:"change_mission"`
+	ChangeMap     []string `json:"change_map"`
+	ChangeMod     []string `json:"change_mod"`
+	Note          string   `json:"note"`
+	Active        int      `json:"active"`
+	Size          string   `json:"size"`
+	ReallifeRpg   int      `json:"realliferpg"`
+	ReleaseAt     string   `json:"release_at"`
+	CreatedAt     string   `json:"created_at"`
+	UpdatedAt     string   `json:"updated_at"`
+}
@@ -0,0 +1,34 @@
+// Package deadletter persists webhook deliveries that failed after
+// exhausting their retry policy, so an operator can inspect and re-drive
+// them later instead of losing the notification entirely.
+package deadletter
+
+import (
+	"context"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+)
+
+// Item is a single undelivered webhook payload. It intentionally stores only
+// the destination WebhookURL rather than the webhook's full notifier.Config:
+// that config can carry an HMAC secret or client TLS key, and the queue is
+// persisted as plain files on disk. Redelivery re-resolves the full config
+// for WebhookURL from the live config instead.
+type Item struct {
+	ID         string              `json:"id"`
+	WebhookURL string              `json:"webhook_url"`
+	Changelog  changelog.Changelog `json:"changelog"`
+	Error      string              `json:"error"`
+	FailedAt   string              `json:"failed_at"`
+}
+
+// Queue stores undelivered Items and lets an operator list and remove them
+// once re-driven.
+type Queue interface {
+	// Enqueue persists item.
+	Enqueue(ctx context.Context, item Item) error
+	// List returns every item currently queued.
+	List(ctx context.Context) ([]Item, error)
+	// Remove deletes the item with the given id.
+	Remove(ctx context.Context, id string) error
+}
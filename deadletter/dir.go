@@ -0,0 +1,78 @@
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DirQueue stores each Item as a JSON file in a directory, so it survives a
+// process restart without needing a database.
+type DirQueue struct {
+	dir string
+}
+
+// NewDirQueue builds a DirQueue backed by dir, creating it if necessary.
+func NewDirQueue(dir string) (*DirQueue, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("error creating dead-letter directory: %w", err)
+	}
+
+	return &DirQueue{dir: dir}, nil
+}
+
+func (q *DirQueue) path(id string) string {
+	return filepath.Join(q.dir, id+".json")
+}
+
+func (q *DirQueue) Enqueue(ctx context.Context, item Item) error {
+	data, err := json.MarshalIndent(item, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling dead-letter item: %w", err)
+	}
+
+	if err := os.WriteFile(q.path(item.ID), data, 0600); err != nil {
+		return fmt.Errorf("error writing dead-letter item: %w", err)
+	}
+
+	return nil
+}
+
+func (q *DirQueue) List(ctx context.Context) ([]Item, error) {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading dead-letter directory: %w", err)
+	}
+
+	var items []Item
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(q.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading dead-letter item %q: %w", entry.Name(), err)
+		}
+
+		var item Item
+		if err := json.Unmarshal(data, &item); err != nil {
+			return nil, fmt.Errorf("error unmarshaling dead-letter item %q: %w", entry.Name(), err)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (q *DirQueue) Remove(ctx context.Context, id string) error {
+	if err := os.Remove(q.path(id)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing dead-letter item: %w", err)
+	}
+
+	return nil
+}
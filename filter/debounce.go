@@ -0,0 +1,60 @@
+package filter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+	"github.com/tklein1801/panthor-update-notification/store"
+)
+
+type debounceState struct {
+	Version    string    `json:"version"`
+	NotifiedAt time.Time `json:"notified_at"`
+}
+
+func debounceKey(webhookURL string) string {
+	return "debounce:" + webhookURL
+}
+
+// Debounced reports whether webhookURL was already notified about c within
+// cfg's debounce window, using st to recall the last notification so the
+// check survives a restart. An empty Debounce disables the check.
+func Debounced(ctx context.Context, st store.Store, webhookURL string, cfg Config, c changelog.Changelog, now time.Time) (bool, error) {
+	if cfg.Debounce == "" {
+		return false, nil
+	}
+
+	window, err := time.ParseDuration(cfg.Debounce)
+	if err != nil {
+		return false, fmt.Errorf("invalid debounce %q: %w", cfg.Debounce, err)
+	}
+
+	raw, err := st.Get(ctx, debounceKey(webhookURL))
+	if err != nil {
+		return false, err
+	}
+	if raw == "" {
+		return false, nil
+	}
+
+	var state debounceState
+	if err := json.Unmarshal([]byte(raw), &state); err != nil {
+		return false, fmt.Errorf("error parsing debounce state: %w", err)
+	}
+
+	return state.Version == c.Version && now.Sub(state.NotifiedAt) < window, nil
+}
+
+// MarkNotified records that webhookURL was just notified about c, so a
+// later Debounced call within the debounce window returns true.
+func MarkNotified(ctx context.Context, st store.Store, webhookURL string, c changelog.Changelog, now time.Time) error {
+	data, err := json.Marshal(debounceState{Version: c.Version, NotifiedAt: now})
+	if err != nil {
+		return fmt.Errorf("error marshaling debounce state: %w", err)
+	}
+
+	return st.Set(ctx, debounceKey(webhookURL), string(data))
+}
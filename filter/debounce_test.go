@@ -0,0 +1,126 @@
+package filter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+	"github.com/tklein1801/panthor-update-notification/store"
+)
+
+func newTestStore(t *testing.T) store.Store {
+	t.Helper()
+	return store.NewFileStore(filepath.Join(t.TempDir(), "state.yml"))
+}
+
+func TestDebouncedDisabledWhenUnset(t *testing.T) {
+	st := newTestStore(t)
+	ctx := context.Background()
+
+	debounced, err := Debounced(ctx, st, "https://example.com/hook", Config{}, changelog.Changelog{Version: "1.0.0"}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if debounced {
+		t.Error("expected an unset debounce window to never suppress")
+	}
+}
+
+func TestDebouncedWithinWindow(t *testing.T) {
+	st := newTestStore(t)
+	ctx := context.Background()
+	cfg := Config{Debounce: "30m"}
+	c := changelog.Changelog{Version: "1.0.0"}
+	now := time.Now()
+
+	if err := MarkNotified(ctx, st, "https://example.com/hook", c, now); err != nil {
+		t.Fatalf("MarkNotified failed: %v", err)
+	}
+
+	debounced, err := Debounced(ctx, st, "https://example.com/hook", cfg, c, now.Add(10*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !debounced {
+		t.Error("expected a re-notify within the debounce window to be suppressed")
+	}
+}
+
+func TestDebouncedAfterWindowExpires(t *testing.T) {
+	st := newTestStore(t)
+	ctx := context.Background()
+	cfg := Config{Debounce: "30m"}
+	c := changelog.Changelog{Version: "1.0.0"}
+	now := time.Now()
+
+	if err := MarkNotified(ctx, st, "https://example.com/hook", c, now); err != nil {
+		t.Fatalf("MarkNotified failed: %v", err)
+	}
+
+	debounced, err := Debounced(ctx, st, "https://example.com/hook", cfg, c, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if debounced {
+		t.Error("expected a re-notify after the debounce window to proceed")
+	}
+}
+
+func TestDebouncedDifferentVersion(t *testing.T) {
+	st := newTestStore(t)
+	ctx := context.Background()
+	cfg := Config{Debounce: "30m"}
+	now := time.Now()
+
+	if err := MarkNotified(ctx, st, "https://example.com/hook", changelog.Changelog{Version: "1.0.0"}, now); err != nil {
+		t.Fatalf("MarkNotified failed: %v", err)
+	}
+
+	debounced, err := Debounced(ctx, st, "https://example.com/hook", cfg, changelog.Changelog{Version: "1.0.1"}, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if debounced {
+		t.Error("expected a different version to not be debounced")
+	}
+}
+
+func TestPendingVersionRoundTrip(t *testing.T) {
+	st := newTestStore(t)
+	ctx := context.Background()
+	c := changelog.Changelog{Version: "1.0.0"}
+
+	version, err := PendingVersion(ctx, st, "https://example.com/hook")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != "" {
+		t.Errorf("expected no pending version initially, got %q", version)
+	}
+
+	if err := MarkPending(ctx, st, "https://example.com/hook", c); err != nil {
+		t.Fatalf("MarkPending failed: %v", err)
+	}
+
+	version, err = PendingVersion(ctx, st, "https://example.com/hook")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != c.Version {
+		t.Errorf("expected pending version %q, got %q", c.Version, version)
+	}
+
+	if err := ClearPending(ctx, st, "https://example.com/hook"); err != nil {
+		t.Fatalf("ClearPending failed: %v", err)
+	}
+
+	version, err = PendingVersion(ctx, st, "https://example.com/hook")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != "" {
+		t.Errorf("expected pending version to be cleared, got %q", version)
+	}
+}
@@ -0,0 +1,39 @@
+package filter
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+)
+
+// evalExpr compiles and runs expression as a boolean expr-lang expression
+// over c's fields.
+func evalExpr(expression string, c changelog.Changelog) (bool, error) {
+	env := map[string]any{
+		"Version":       c.Version,
+		"Size":          c.Size,
+		"Note":          c.Note,
+		"ChangeMission": c.ChangeMission,
+		"ChangeMap":     c.ChangeMap,
+		"ChangeMod":     c.ChangeMod,
+	}
+
+	program, err := expr.Compile(expression, expr.Env(env), expr.AsBool())
+	if err != nil {
+		return false, fmt.Errorf("error compiling expr: %w", err)
+	}
+
+	output, err := expr.Run(program, env)
+	if err != nil {
+		return false, fmt.Errorf("error running expr: %w", err)
+	}
+
+	result, ok := output.(bool)
+	if !ok {
+		return false, fmt.Errorf("expr must evaluate to a bool, got %T", output)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,114 @@
+// Package filter decides, per webhook, whether a given changelog is worth
+// notifying about and when it is allowed to go out.
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+)
+
+// Config is the `filter:` section of a webhook entry in config.yml. The
+// match rules (every field but Debounce and QuietHours) are ANDed together:
+// a changelog must satisfy every one that is set for Match to return true.
+// Debounce and QuietHours are checked separately, by Debounced and
+// InQuietHours.
+type Config struct {
+	HasModUpdate   *bool  `yaml:"has_mod_update"`
+	MissionChanged *bool  `yaml:"mission_changed"`
+	MapChanged     *bool  `yaml:"map_changed"`
+	SizeGT         string `yaml:"size_gt"`
+
+	// Expr is an expr-lang (github.com/expr-lang/expr) boolean expression
+	// evaluated over the Changelog's fields, for rules the structured
+	// options above can't express.
+	Expr string `yaml:"expr"`
+
+	// Debounce suppresses re-notifying about the same version within this
+	// window, as a time.ParseDuration string (e.g. "30m").
+	Debounce string `yaml:"debounce"`
+
+	// QuietHours suppresses notifications during a daily UTC window.
+	QuietHours QuietHoursConfig `yaml:"quiet_hours"`
+}
+
+// Match reports whether c satisfies every rule configured in cfg.
+func Match(cfg Config, c changelog.Changelog) (bool, error) {
+	if cfg.HasModUpdate != nil && *cfg.HasModUpdate != (len(c.ChangeMod) > 0) {
+		return false, nil
+	}
+	if cfg.MissionChanged != nil && *cfg.MissionChanged != (len(c.ChangeMission) > 0) {
+		return false, nil
+	}
+	if cfg.MapChanged != nil && *cfg.MapChanged != (len(c.ChangeMap) > 0) {
+		return false, nil
+	}
+
+	if cfg.SizeGT != "" {
+		threshold, err := parseSize(cfg.SizeGT)
+		if err != nil {
+			return false, fmt.Errorf("invalid size_gt: %w", err)
+		}
+
+		size, err := parseSize(c.Size)
+		if err != nil {
+			return false, fmt.Errorf("invalid changelog size %q: %w", c.Size, err)
+		}
+
+		if size <= threshold {
+			return false, nil
+		}
+	}
+
+	if cfg.Expr != "" {
+		ok, err := evalExpr(cfg.Expr, c)
+		if err != nil {
+			return false, fmt.Errorf("error evaluating filter expr: %w", err)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// sizeSuffixes must be checked longest-suffix-first, since "MB" and "KB"
+// both end in "B".
+var sizeSuffixes = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"GB", 1024 * 1024 * 1024},
+	{"MB", 1024 * 1024},
+	{"KB", 1024},
+	{"B", 1},
+}
+
+// parseSize parses a human size like "500MB" into a byte count.
+func parseSize(s string) (int64, error) {
+	upper := strings.ToUpper(strings.TrimSpace(s))
+
+	for _, sfx := range sizeSuffixes {
+		if !strings.HasSuffix(upper, sfx.suffix) {
+			continue
+		}
+
+		numPart := strings.TrimSpace(strings.TrimSuffix(upper, sfx.suffix))
+		value, err := strconv.ParseFloat(numPart, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid size %q", s)
+		}
+
+		return int64(value * float64(sfx.multiplier)), nil
+	}
+
+	value, err := strconv.ParseFloat(upper, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+
+	return int64(value), nil
+}
@@ -0,0 +1,83 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestMatchHasModUpdate(t *testing.T) {
+	cfg := Config{HasModUpdate: boolPtr(true)}
+
+	match, err := Match(cfg, changelog.Changelog{ChangeMod: []string{"fixed weapon"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !match {
+		t.Error("expected a changelog with ChangeMod entries to match has_mod_update: true")
+	}
+
+	match, err = Match(cfg, changelog.Changelog{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if match {
+		t.Error("expected a changelog without ChangeMod entries to not match has_mod_update: true")
+	}
+}
+
+func TestMatchSizeGT(t *testing.T) {
+	cfg := Config{SizeGT: "500MB"}
+
+	match, err := Match(cfg, changelog.Changelog{Size: "1GB"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !match {
+		t.Error("expected 1GB to match size_gt: 500MB")
+	}
+
+	match, err = Match(cfg, changelog.Changelog{Size: "100MB"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if match {
+		t.Error("expected 100MB to not match size_gt: 500MB")
+	}
+}
+
+func TestMatchExpr(t *testing.T) {
+	cfg := Config{Expr: `len(ChangeMission) > 0 && Version != "0.0.0"`}
+
+	match, err := Match(cfg, changelog.Changelog{Version: "1.0.0", ChangeMission: []string{"new objective"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !match {
+		t.Error("expected expr to match")
+	}
+}
+
+func TestParseSizeSuffixOrdering(t *testing.T) {
+	cases := map[string]int64{
+		"1B":    1,
+		"1KB":   1024,
+		"1MB":   1024 * 1024,
+		"1GB":   1024 * 1024 * 1024,
+		"500mb": 500 * 1024 * 1024,
+		"42":    42,
+	}
+
+	for in, want := range cases {
+		got, err := parseSize(in)
+		if err != nil {
+			t.Errorf("parseSize(%q) returned error: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("parseSize(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
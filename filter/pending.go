@@ -0,0 +1,31 @@
+package filter
+
+import (
+	"context"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+	"github.com/tklein1801/panthor-update-notification/store"
+)
+
+func pendingKey(webhookURL string) string {
+	return "pending:" + webhookURL
+}
+
+// PendingVersion returns the version webhookURL was deferred on (e.g. by
+// quiet hours), or "" if it has no deferred delivery outstanding.
+func PendingVersion(ctx context.Context, st store.Store, webhookURL string) (string, error) {
+	return st.Get(ctx, pendingKey(webhookURL))
+}
+
+// MarkPending records that webhookURL still owes a delivery for c, so that a
+// later tick retries it even if the global version pointer has already
+// advanced past c.
+func MarkPending(ctx context.Context, st store.Store, webhookURL string, c changelog.Changelog) error {
+	return st.Set(ctx, pendingKey(webhookURL), c.Version)
+}
+
+// ClearPending removes webhookURL's deferred delivery, once it has been
+// resolved (delivered, permanently filtered out, or errored).
+func ClearPending(ctx context.Context, st store.Store, webhookURL string) error {
+	return st.Set(ctx, pendingKey(webhookURL), "")
+}
@@ -0,0 +1,55 @@
+package filter
+
+import (
+	"fmt"
+	"time"
+)
+
+// QuietHoursConfig defines a daily UTC window during which notifications
+// are suppressed. A window that wraps midnight (Start > End) is supported,
+// e.g. Start: "22:00", End: "07:00".
+type QuietHoursConfig struct {
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+}
+
+// InQuietHours reports whether now falls inside cfg's quiet-hours window.
+// An unset Start or End disables the check.
+func InQuietHours(cfg QuietHoursConfig, now time.Time) (bool, error) {
+	if cfg.Start == "" || cfg.End == "" {
+		return false, nil
+	}
+
+	start, err := parseClock(cfg.Start)
+	if err != nil {
+		return false, fmt.Errorf("invalid quiet_hours.start: %w", err)
+	}
+
+	end, err := parseClock(cfg.End)
+	if err != nil {
+		return false, fmt.Errorf("invalid quiet_hours.end: %w", err)
+	}
+
+	current := now.UTC().Hour()*60 + now.UTC().Minute()
+
+	if start <= end {
+		return current >= start && current < end, nil
+	}
+
+	// The window wraps midnight, e.g. 22:00-07:00.
+	return current >= start || current < end, nil
+}
+
+// parseClock parses an "HH:MM" string into minutes since midnight.
+func parseClock(s string) (int, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(s, "%d:%d", &h, &m); err != nil {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+
+	if h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+
+	return h*60 + m, nil
+}
@@ -0,0 +1,78 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInQuietHoursDisabledWhenUnset(t *testing.T) {
+	inQuietHours, err := InQuietHours(QuietHoursConfig{}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inQuietHours {
+		t.Error("expected an unset quiet_hours window to never suppress")
+	}
+}
+
+func TestInQuietHoursNonWrapping(t *testing.T) {
+	cfg := QuietHoursConfig{Start: "09:00", End: "17:00"}
+
+	cases := map[string]bool{
+		"2026-07-25T12:00:00Z": true,
+		"2026-07-25T09:00:00Z": true,
+		"2026-07-25T17:00:00Z": false,
+		"2026-07-25T08:59:00Z": false,
+		"2026-07-25T20:00:00Z": false,
+	}
+
+	for ts, want := range cases {
+		now, err := time.Parse(time.RFC3339, ts)
+		if err != nil {
+			t.Fatalf("bad fixture time: %v", err)
+		}
+
+		got, err := InQuietHours(cfg, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("InQuietHours(%s) = %v, want %v", ts, got, want)
+		}
+	}
+}
+
+func TestInQuietHoursMidnightWraparound(t *testing.T) {
+	cfg := QuietHoursConfig{Start: "22:00", End: "07:00"}
+
+	cases := map[string]bool{
+		"2026-07-25T23:00:00Z": true,
+		"2026-07-25T03:00:00Z": true,
+		"2026-07-25T06:59:00Z": true,
+		"2026-07-25T07:00:00Z": false,
+		"2026-07-25T21:59:00Z": false,
+		"2026-07-25T22:00:00Z": true,
+	}
+
+	for ts, want := range cases {
+		now, err := time.Parse(time.RFC3339, ts)
+		if err != nil {
+			t.Fatalf("bad fixture time: %v", err)
+		}
+
+		got, err := InQuietHours(cfg, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("InQuietHours(%s) = %v, want %v", ts, got, want)
+		}
+	}
+}
+
+func TestInQuietHoursInvalidClock(t *testing.T) {
+	_, err := InQuietHours(QuietHoursConfig{Start: "25:00", End: "07:00"}, time.Now())
+	if err == nil {
+		t.Error("expected an invalid start time to return an error")
+	}
+}
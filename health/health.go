@@ -0,0 +1,60 @@
+// Package health tracks whether the daemon is ready to serve traffic, for
+// use by the /livez and /readyz endpoints.
+package health
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Status records when the most recent successful changelog poll completed
+// and exposes a readiness check built from it.
+type Status struct {
+	mu            sync.RWMutex
+	lastSuccessAt time.Time
+	maxPollAge    time.Duration
+	storeWritable func() error
+}
+
+// New builds a Status that considers the daemon ready as long as a poll
+// succeeded within maxPollAge and storeWritable reports no error.
+func New(maxPollAge time.Duration, storeWritable func() error) *Status {
+	return &Status{maxPollAge: maxPollAge, storeWritable: storeWritable}
+}
+
+// RecordPoll records the outcome of a changelog poll. Only a successful poll
+// moves the readiness window forward; a failed one leaves it unchanged.
+func (s *Status) RecordPoll(ok bool) {
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastSuccessAt = time.Now()
+}
+
+// Ready returns nil if the daemon is ready to serve traffic, or an error
+// describing why it isn't.
+func (s *Status) Ready() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.lastSuccessAt.IsZero() {
+		return fmt.Errorf("no changelog poll has succeeded yet")
+	}
+
+	if age := time.Since(s.lastSuccessAt); age > s.maxPollAge {
+		return fmt.Errorf("last successful poll was %s ago, exceeding %s", age, s.maxPollAge)
+	}
+
+	if s.storeWritable != nil {
+		if err := s.storeWritable(); err != nil {
+			return fmt.Errorf("store is not writable: %w", err)
+		}
+	}
+
+	return nil
+}
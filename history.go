@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/tklein1801/panthor-update-notification/store"
+)
+
+// defaultHistoryLimit is how many entries `panthor-notify history` prints
+// when no limit is given on the command line.
+const defaultHistoryLimit = 20
+
+// runHistory implements `panthor-notify history [n]`: it prints the n most
+// recent notified versions and their per-webhook delivery status as JSON, so
+// an operator can answer "what did we send and when" without reaching into
+// the store backend directly. n defaults to defaultHistoryLimit; passing a
+// negative n prints the full history.
+func runHistory(args []string) error {
+	limit := defaultHistoryLimit
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid history limit %q: %w", args[0], err)
+		}
+		limit = n
+	}
+
+	config, err := LoadConfig("config.yml")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	st, err := store.New(config.Store)
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+	defer st.Close()
+
+	entries, err := st.History(context.Background(), limit)
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(entries)
+}
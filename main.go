@@ -1,156 +1,211 @@
 package main
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
-	"strconv"
+	"strings"
+	"time"
 
 	"github.com/robfig/cron"
 	"gopkg.in/yaml.v3"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+	"github.com/tklein1801/panthor-update-notification/deadletter"
+	"github.com/tklein1801/panthor-update-notification/health"
+	"github.com/tklein1801/panthor-update-notification/notifier"
+	"github.com/tklein1801/panthor-update-notification/server"
+	"github.com/tklein1801/panthor-update-notification/store"
 )
 
+// defaultMaxPollAge is how long /readyz tolerates not having seen a
+// successful poll before reporting not-ready, when server.max_poll_age
+// isn't set.
+const defaultMaxPollAge = 15 * time.Minute
+
+// defaultDeadLetterDir is where undelivered webhook payloads are persisted
+// when dead_letter.dir isn't set.
+const defaultDeadLetterDir = "dead-letter"
+
 type Config struct {
 	App struct {
 		Interval      string `yaml:"interval"`
 		LoadOnStartup bool   `yaml:"load_on_startup"`
 	} `yaml:"app"`
 	Notification struct {
-		Webhooks []string `yaml:"webhooks"`
+		Webhooks []notifier.Config `yaml:"webhooks"`
 	} `yaml:"notification"`
+	Store      store.Config     `yaml:"store"`
+	Log        LogConfig        `yaml:"log"`
+	Server     ServerConfig     `yaml:"server"`
+	DeadLetter DeadLetterConfig `yaml:"dead_letter"`
 }
 
-type Version struct {
-	Version string `yaml:"version"`
+// LogConfig controls the verbosity and encoding of the daemon's logs.
+type LogConfig struct {
+	// Level is one of debug, info, warn, error. Defaults to info.
+	Level string `yaml:"level"`
+	// Format is one of text, json. Defaults to text.
+	Format string `yaml:"format"`
 }
 
-type ChangelogResponse struct {
-	Data        []Changelog `json:"data"`
-	RequestedAt int         `json:"requested_at"`
+// ServerConfig controls the embedded /livez, /readyz and /metrics HTTP
+// server. The server is disabled if Addr is empty.
+type ServerConfig struct {
+	// Addr is the address to listen on, e.g. ":8080".
+	Addr string `yaml:"addr"`
+	// MaxPollAge is how long since the last successful poll before /readyz
+	// reports not-ready, as a time.ParseDuration string. Defaults to 15m.
+	MaxPollAge string `yaml:"max_poll_age"`
 }
 
-type Changelog struct {
-	ID            int      `json:"id"`
-	Version       string   `json:"version"`
-	ChangeMission []string `json:"change_mission"`
-	ChangeMap     []string `json:"change_map"`
-	ChangeMod     []string `json:"change_mod"`
-	Note          string   `json:"note"`
-	Active        int      `json:"active"`
-	Size          string   `json:"size"`
-	ReallifeRpg   int      `json:"realliferpg"`
-	ReleaseAt     string   `json:"release_at"`
-	CreatedAt     string   `json:"created_at"`
-	UpdatedAt     string   `json:"updated_at"`
+// DeadLetterConfig controls where webhook deliveries are parked once they
+// exhaust their retry policy.
+type DeadLetterConfig struct {
+	// Dir is the directory undelivered payloads are written to. Defaults
+	// to "dead-letter".
+	Dir string `yaml:"dir"`
 }
 
 func main() {
-	config, err := LoadConfig("config.yml")
-	if err != nil {
-		log.Fatalln("Failed to load config:", err)
+	// `panthor-notify redeliver` re-drives everything sitting in the
+	// dead-letter queue, and `panthor-notify history [n]` prints past
+	// deliveries, instead of running the normal poll loop.
+	if len(os.Args) > 1 && os.Args[1] == "redeliver" {
+		if err := runRedeliver(); err != nil {
+			slog.Error("redeliver failed", "error", err)
+			os.Exit(1)
+		}
 		return
 	}
-
-	if config.App.LoadOnStartup || !DoesFileExist("version.yml") {
-		changelogs, err := GetChangelogs()
-		if err != nil {
-			log.Fatalln("Failed to get changelogs:", err)
-			return
-		}
-
-		if len(*changelogs) == 0 {
-			log.Fatalln("no changelogs found")
-			return
+	if len(os.Args) > 1 && os.Args[1] == "history" {
+		if err := runHistory(os.Args[2:]); err != nil {
+			slog.Error("history failed", "error", err)
+			os.Exit(1)
 		}
+		return
+	}
 
-		err = SaveVersion((*changelogs)[0].Version)
-		if err != nil {
-			log.Fatalln("Failed to save version:", err)
-			return
-		}
+	// The logger is reconfigured from config.Log right after LoadConfig, but
+	// a default is installed first so that a broken config file is itself
+	// reported through slog.
+	setupLogger(LogConfig{})
 
-		log.Println("Version of the first item:", (*changelogs)[0].Version)
+	config, err := LoadConfig("config.yml")
+	if err != nil {
+		slog.Error("failed to load config", "error", err)
+		os.Exit(1)
 	}
 
-	c := cron.New()
-	c.AddFunc(config.App.Interval, func() {
-		changelogs, err := GetChangelogs()
-		if err != nil {
-			log.Fatalln("Failed to get changelogs:", err)
-			return
-		}
+	setupLogger(config.Log)
 
-		if len(*changelogs) == 0 {
-			log.Fatalln("no changelogs found")
-			return
-		}
+	st, err := store.New(config.Store)
+	if err != nil {
+		slog.Error("failed to initialize store", "error", err)
+		os.Exit(1)
+	}
+	defer st.Close()
 
-		changelog := (*changelogs)[0]
-		savedVersion, err := GetSavedVerison()
-		if err != nil {
-			log.Fatalln("Failed to get saved version:", err)
-			return
-		}
+	queue, err := deadletter.NewDirQueue(deadLetterDir(config.DeadLetter))
+	if err != nil {
+		slog.Error("failed to initialize dead-letter queue", "error", err)
+		os.Exit(1)
+	}
 
-		if changelog.Version == savedVersion.Version {
-			log.Println("Version is the same! No new version avaiable.")
-			return
-		}
+	status := health.New(maxPollAge(config.Server), func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		return st.Set(ctx, "health:probe", time.Now().UTC().Format(time.RFC3339))
+	})
 
-		log.Println("New version is", changelog.Version)
+	if config.Server.Addr != "" {
+		go func() {
+			slog.Info("starting health/metrics server", "addr", config.Server.Addr)
+			if err := http.ListenAndServe(config.Server.Addr, server.New(status)); err != nil {
+				slog.Error("health/metrics server stopped", "error", err)
+			}
+		}()
+	}
 
-		err = SaveVersion(changelog.Version)
-		if err != nil {
-			log.Fatalln("Failed to save version:", err)
-			return
+	if config.App.LoadOnStartup {
+		if err := checkForUpdate(config, st, status, queue); err != nil {
+			slog.Error("failed initial update check", "error", err)
+			os.Exit(1)
 		}
+	}
 
-		// Notifications
-		for _, webhook := range config.Notification.Webhooks {
-			requestBody, err := json.Marshal(map[string]interface{}{
-				"content":      fmt.Sprintf("New version %s is available!", changelog.Version),
-				"version":      changelog.Version,
-				"size":         changelog.Size,
-				"hasModUpdate": strconv.FormatBool(len(changelog.ChangeMod) > 0),
-				"releaseAt":    changelog.ReleaseAt,
-			})
-			if err != nil {
-				log.Println("Failed to marshal request body:", err)
-				continue
-			}
-
-			err = TriggerWebhook(webhook, requestBody)
-			if err != nil {
-				log.Println("Failed to trigger webhook:", err)
-				continue
-			}
+	c := cron.New()
+	c.AddFunc(config.App.Interval, func() {
+		// A transient API failure shouldn't kill the daemon, so errors are
+		// logged and the next tick gets another chance instead of exiting.
+		if err := checkForUpdate(config, st, status, queue); err != nil {
+			slog.Error("update check failed", "error", err)
 		}
 	})
 
 	c.Start()
 
-	log.Println("Panthor Update Notification started...")
+	slog.Info("Panthor Update Notification started...")
 
 	select {}
 }
 
-// TriggerWebhook sends a POST request to the specified webhook URL with the given request body.
-// It returns an error if the request fails or if the response status code is not 200 OK.
-func TriggerWebhook(webhook string, requestBody []byte) error {
-	resp, err := http.Post(webhook, "application/json", bytes.NewBuffer(requestBody))
+// deadLetterDir resolves the configured dead-letter directory, falling
+// back to defaultDeadLetterDir if it is unset.
+func deadLetterDir(cfg DeadLetterConfig) string {
+	if cfg.Dir == "" {
+		return defaultDeadLetterDir
+	}
+	return cfg.Dir
+}
+
+// maxPollAge resolves the configured readiness window, falling back to
+// defaultMaxPollAge if it is unset or invalid.
+func maxPollAge(cfg ServerConfig) time.Duration {
+	if cfg.MaxPollAge == "" {
+		return defaultMaxPollAge
+	}
+
+	d, err := time.ParseDuration(cfg.MaxPollAge)
 	if err != nil {
-		return fmt.Errorf("error making POST request: %w", err)
+		slog.Warn("invalid server.max_poll_age, using default", "value", cfg.MaxPollAge, "default", defaultMaxPollAge)
+		return defaultMaxPollAge
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	return d
+}
+
+// setupLogger builds a slog.Logger from cfg and installs it as the default
+// logger, so every package (notifier, store) can just call slog.Default().
+func setupLogger(cfg LogConfig) {
+	opts := &slog.HandlerOptions{Level: parseLevel(cfg.Level)}
+
+	var handler slog.Handler
+	if strings.EqualFold(cfg.Format, "json") {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	} else {
+		handler = slog.NewTextHandler(os.Stdout, opts)
 	}
 
-	return nil
+	slog.SetDefault(slog.New(handler))
+}
+
+// parseLevel maps a config string to a slog.Level, defaulting to info for
+// an empty or unrecognized value.
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
 }
 
 // LoadConfig loads the configuration from the specified file.
@@ -172,18 +227,11 @@ func LoadConfig(filename string) (*Config, error) {
 	return &config, nil
 }
 
-// DoesFileExist checks if a file exists in the given path.
-// It returns true if the file exists, and false otherwise.
-func DoesFileExist(filename string) bool {
-	_, err := os.Stat(filename)
-	return !os.IsNotExist(err)
-}
-
 // GetChangelogs retrieves the changelogs from the Panthor API.
 // It sends a GET request to the specified URL and parses the response into a list of Changelog structs.
 // If successful, it returns a pointer to the list of Changelogs and nil error.
 // If an error occurs during the HTTP request or response parsing, it returns nil and an error.
-func GetChangelogs() (*[]Changelog, error) {
+func GetChangelogs() (*[]changelog.Changelog, error) {
 	url := "https://api.panthor.de/v1/changelog"
 	resp, err := http.Get(url)
 	if err != nil {
@@ -195,7 +243,7 @@ func GetChangelogs() (*[]Changelog, error) {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	var parsedResponse ChangelogResponse
+	var parsedResponse changelog.Response
 	err = json.NewDecoder(resp.Body).Decode(&parsedResponse)
 	if err != nil {
 		return nil, fmt.Errorf("error decoding response body: %w", err)
@@ -203,44 +251,3 @@ func GetChangelogs() (*[]Changelog, error) {
 
 	return &parsedResponse.Data, nil
 }
-
-// GetSavedVerison reads the version information from the "version.yml" file and returns it as a Version struct.
-// If there is an error reading the file or parsing the YAML, an error is returned.
-func GetSavedVerison() (*Version, error) {
-	data, err := os.ReadFile("version.yml")
-	if err != nil {
-		return nil, fmt.Errorf("error reading the file: %w", err)
-	}
-
-	var version Version
-
-	err = yaml.Unmarshal(data, &version)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing the YAML file: %w", err)
-	}
-
-	return &version, nil
-}
-
-// SaveVersion saves the given version string to a YAML file named "version.yml".
-// It marshals the version data to YAML format and writes it to the file.
-// If any error occurs during the process, it returns an error.
-// The file permissions for the created file are set to 0644.
-//
-// Parameters:
-//   - version: The version string to be saved.
-//
-// Returns:
-//   - error: An error if any occurred during the process, otherwise nil.
-func SaveVersion(version string) error {
-	data := Version{Version: version}
-	yamlData, err := yaml.Marshal(data)
-	if err != nil {
-		return fmt.Errorf("error marshaling data to YAML: %w", err)
-	}
-	err = os.WriteFile("version.yml", yamlData, 0644)
-	if err != nil {
-		return fmt.Errorf("error writing to file: %w", err)
-	}
-	return nil
-}
@@ -0,0 +1,54 @@
+// Package metrics defines the Prometheus instrumentation exposed on the
+// daemon's /metrics endpoint.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	// PollTotal counts changelog polls, labeled by result ("success" or
+	// "error").
+	PollTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "panthor_changelog_poll_total",
+		Help: "Total number of changelog API polls, labeled by result.",
+	}, []string{"result"})
+
+	// PollDuration observes how long a changelog poll took.
+	PollDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name: "panthor_poll_duration_seconds",
+		Help: "Duration of changelog API polls in seconds.",
+	})
+
+	// CurrentVersion is set to 1 for the version label that is currently
+	// known to be the latest, and reset for every previous one. Use
+	// SetCurrentVersion instead of setting it directly, so that previous
+	// versions don't pile up as stale series.
+	CurrentVersion = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "panthor_current_version",
+		Help: "The currently known Panthor version (value is always 1).",
+	}, []string{"version"})
+
+	// WebhookDeliveryTotal counts webhook deliveries, labeled by the
+	// destination url and the outcome status.
+	WebhookDeliveryTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "panthor_webhook_delivery_total",
+		Help: "Total number of webhook deliveries, labeled by url and status.",
+	}, []string{"url", "status"})
+
+	// WebhookDuration observes how long a webhook delivery took.
+	WebhookDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "panthor_webhook_duration_seconds",
+		Help: "Duration of webhook deliveries in seconds.",
+	}, []string{"url"})
+)
+
+func init() {
+	prometheus.MustRegister(PollTotal, PollDuration, CurrentVersion, WebhookDeliveryTotal, WebhookDuration)
+}
+
+// SetCurrentVersion records version as the currently known latest version,
+// clearing the series for whichever version held that spot before so
+// panthor_current_version never grows beyond a single active label.
+func SetCurrentVersion(version string) {
+	CurrentVersion.Reset()
+	CurrentVersion.WithLabelValues(version).Set(1)
+}
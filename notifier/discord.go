@@ -0,0 +1,91 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+)
+
+const defaultDiscordTemplate = `A new version **{{.Version}}** is available!`
+
+// Discord delivers changelogs as Discord embeds via an incoming webhook URL.
+type Discord struct {
+	url      string
+	template string
+	client   *http.Client
+}
+
+// NewDiscord builds a Discord notifier from cfg.
+func NewDiscord(cfg Config) (*Discord, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("discord notifier requires a url")
+	}
+
+	tlsConfig, err := buildTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Discord{url: cfg.URL, template: cfg.Template, client: newHTTPClient(parseTimeout(cfg.Timeout), tlsConfig)}, nil
+}
+
+type discordPayload struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Color       int                 `json:"color"`
+	Fields      []discordEmbedField `json:"fields,omitempty"`
+	Timestamp   string              `json:"timestamp,omitempty"`
+}
+
+type discordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// Send posts a Discord embed describing c to the webhook URL.
+func (d *Discord) Send(ctx context.Context, c changelog.Changelog) error {
+	description, err := renderTemplate(d.template, defaultDiscordTemplate, c)
+	if err != nil {
+		return err
+	}
+
+	embed := discordEmbed{
+		Title:       fmt.Sprintf("Panthor %s", c.Version),
+		Description: description,
+		Color:       0x2ecc71,
+		Fields: []discordEmbedField{
+			{Name: "Size", Value: c.Size, Inline: true},
+			{Name: "Released At", Value: c.ReleaseAt, Inline: true},
+		},
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if len(c.ChangeMission) > 0 {
+		embed.Fields = append(embed.Fields, discordEmbedField{Name: "Missions", Value: joinLines(c.ChangeMission)})
+	}
+	if len(c.ChangeMap) > 0 {
+		embed.Fields = append(embed.Fields, discordEmbedField{Name: "Maps", Value: joinLines(c.ChangeMap)})
+	}
+	if len(c.ChangeMod) > 0 {
+		embed.Fields = append(embed.Fields, discordEmbedField{Name: "Mods", Value: joinLines(c.ChangeMod)})
+	}
+	if c.Note != "" {
+		embed.Fields = append(embed.Fields, discordEmbedField{Name: "Note", Value: c.Note})
+	}
+
+	body, err := json.Marshal(discordPayload{Embeds: []discordEmbed{embed}})
+	if err != nil {
+		return fmt.Errorf("error marshaling discord payload: %w", err)
+	}
+
+	return postJSON(ctx, d.client, d.url, body, nil)
+}
@@ -0,0 +1,69 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+)
+
+// Generic delivers the raw Changelog (or a user-defined template) as a JSON
+// POST body. When cfg.Secret is set, every request is signed so the receiver can
+// verify it actually came from this daemon.
+type Generic struct {
+	url      string
+	template string
+	secret   string
+	client   *http.Client
+}
+
+// NewGeneric builds a generic-json notifier from cfg.
+func NewGeneric(cfg Config) (*Generic, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("generic-json notifier requires a url")
+	}
+
+	tlsConfig, err := buildTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Generic{
+		url:      cfg.URL,
+		template: cfg.Template,
+		secret:   cfg.Secret,
+		client:   newHTTPClient(parseTimeout(cfg.Timeout), tlsConfig),
+	}, nil
+}
+
+// Send posts c (or the rendered template, if one is configured) to the
+// webhook URL as JSON, signing the request if a secret is configured.
+func (g *Generic) Send(ctx context.Context, c changelog.Changelog) error {
+	var body []byte
+
+	if g.template != "" {
+		rendered, err := renderTemplate(g.template, "", c)
+		if err != nil {
+			return err
+		}
+		body = []byte(rendered)
+	} else {
+		marshaled, err := json.Marshal(c)
+		if err != nil {
+			return fmt.Errorf("error marshaling generic payload: %w", err)
+		}
+		body = marshaled
+	}
+
+	headers := map[string]string{
+		"X-Panthor-Delivery": newDeliveryID(),
+		"X-Panthor-Event":    "version.new",
+	}
+	if g.secret != "" {
+		headers["X-Panthor-Signature"] = "sha256=" + sign(g.secret, body)
+	}
+
+	return postJSON(ctx, g.client, g.url, body, headers)
+}
@@ -0,0 +1,179 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// sharedTransport is reused by every notifier's http.Client that has no
+// custom TLS config (see newHTTPClient), so delivery to
+// many webhooks doesn't open a fresh connection (and do a fresh TLS
+// handshake) per request.
+var sharedTransport = &http.Transport{
+	MaxIdleConns:        100,
+	MaxIdleConnsPerHost: 10,
+	IdleConnTimeout:     90 * time.Second,
+}
+
+// defaultTimeout bounds a single delivery attempt when a webhook doesn't
+// configure its own `timeout`.
+const defaultTimeout = 10 * time.Second
+
+// newHTTPClient builds an *http.Client bounding every request to timeout
+// (or defaultTimeout if timeout is zero), so a slow endpoint can't stall
+// the whole cron tick. When tlsConfig is nil, the client shares
+// sharedTransport; otherwise it gets its own transport carrying tlsConfig,
+// since a custom client certificate or CA is specific to one webhook.
+func newHTTPClient(timeout time.Duration, tlsConfig *tls.Config) *http.Client {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	if tlsConfig == nil {
+		return &http.Client{Transport: sharedTransport, Timeout: timeout}
+	}
+
+	transport := sharedTransport.Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+// TLSConfig configures a webhook's outbound TLS connections with a custom
+// client certificate and/or private CA, as set in a webhook's `tls:` block.
+type TLSConfig struct {
+	CAFile   string `yaml:"ca_file"`
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+}
+
+// buildTLSConfig builds a *tls.Config from cfg, or returns (nil, nil) if
+// cfg is nil.
+func buildTLSConfig(cfg *TLSConfig) (*tls.Config, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if cfg.CAFile != "" {
+		caCert, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading ca_file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("error parsing ca_file %q", cfg.CAFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// parseTimeout parses s as a time.ParseDuration string, returning 0 (which
+// newHTTPClient treats as "use the default") if s is empty or invalid.
+func parseTimeout(s string) time.Duration {
+	if s == "" {
+		return 0
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		slog.Warn("invalid webhook timeout, using default", "value", s, "error", err)
+		return 0
+	}
+
+	return d
+}
+
+// DeliveryError wraps a failed webhook delivery so SendWithRetry can decide
+// whether it is worth retrying.
+type DeliveryError struct {
+	// StatusCode is 0 for network-level failures (no response received).
+	StatusCode int
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *DeliveryError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+func (e *DeliveryError) Unwrap() error { return e.Err }
+
+// Temporary reports whether the failure is worth retrying: network errors
+// (StatusCode == 0), 5xx server errors, and 429 rate limiting.
+func (e *DeliveryError) Temporary() bool {
+	return e.StatusCode == 0 || e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
+}
+
+// postJSON sends body as an application/json POST request to url using
+// client, setting any extra headers (e.g. a webhook signature), and
+// returns a *DeliveryError on network failure or a non-2xx response.
+func postJSON(ctx context.Context, client *http.Client, url string, body []byte, headers map[string]string) error {
+	start := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return &DeliveryError{Err: fmt.Errorf("error making POST request: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	slog.Debug("posted webhook payload", "webhook_url", url, "status_code", resp.StatusCode, "duration_ms", time.Since(start).Milliseconds())
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &DeliveryError{StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	}
+
+	return nil
+}
+
+// parseRetryAfter parses a Retry-After header value, which may be either a
+// number of seconds or an HTTP date, returning 0 if it can't be parsed.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if when, err := http.ParseTime(value); err == nil {
+		return time.Until(when)
+	}
+
+	return 0
+}
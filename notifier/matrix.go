@@ -0,0 +1,94 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+)
+
+const defaultMatrixTemplate = `A new version <strong>{{.Version}}</strong> is available!`
+
+// Matrix delivers changelogs as m.room.message events via the Matrix
+// client-server API. url is expected to be the full
+// `/_matrix/client/v3/rooms/{roomId}/send/m.room.message/{txnId}` endpoint,
+// including the `access_token` query parameter, with a literal `{txnId}`
+// placeholder that Send replaces with a unique transaction id.
+type Matrix struct {
+	url      string
+	template string
+	client   *http.Client
+}
+
+// NewMatrix builds a Matrix notifier from cfg.
+func NewMatrix(cfg Config) (*Matrix, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("matrix notifier requires a url")
+	}
+
+	tlsConfig, err := buildTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Matrix{url: cfg.URL, template: cfg.Template, client: newHTTPClient(parseTimeout(cfg.Timeout), tlsConfig)}, nil
+}
+
+type matrixMessage struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format"`
+	FormattedBody string `json:"formatted_body"`
+}
+
+// Send PUTs an m.room.message event describing c to the configured room.
+func (m *Matrix) Send(ctx context.Context, c changelog.Changelog) error {
+	formattedBody, err := renderTemplate(m.template, defaultMatrixTemplate, c)
+	if err != nil {
+		return err
+	}
+
+	plainBody := fmt.Sprintf("A new version %s is available! Size: %s, released at %s", c.Version, c.Size, c.ReleaseAt)
+	if c.Note != "" {
+		plainBody += fmt.Sprintf(". Note: %s", c.Note)
+		formattedBody += fmt.Sprintf("<br/><strong>Note:</strong> %s", c.Note)
+	}
+
+	message := matrixMessage{
+		MsgType:       "m.text",
+		Body:          plainBody,
+		Format:        "org.matrix.custom.html",
+		FormattedBody: formattedBody,
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("error marshaling matrix payload: %w", err)
+	}
+
+	txnID := fmt.Sprintf("panthor-%d", time.Now().UnixNano())
+	url := strings.Replace(m.url, "{txnId}", txnID, 1)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return &DeliveryError{Err: fmt.Errorf("error making PUT request: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &DeliveryError{StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// Package notifier renders a changelog.Changelog into a platform-specific
+// payload and delivers it to a configured webhook destination.
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+	"github.com/tklein1801/panthor-update-notification/filter"
+)
+
+// Type selects which concrete Notifier implementation a webhook config uses.
+type Type string
+
+const (
+	TypeDiscord     Type = "discord"
+	TypeSlack       Type = "slack"
+	TypeMatrix      Type = "matrix"
+	TypeGenericJSON Type = "generic-json"
+)
+
+// Notifier delivers a changelog to a single destination.
+type Notifier interface {
+	// Send renders c and delivers it to the destination. It returns an error
+	// if rendering or delivery fails.
+	Send(ctx context.Context, c changelog.Changelog) error
+}
+
+// Config describes a single webhook entry from the `notification.webhooks`
+// section of config.yml.
+type Config struct {
+	URL      string `yaml:"url"`
+	Type     Type   `yaml:"type"`
+	Template string `yaml:"template"`
+
+	// Timeout bounds a single delivery attempt, as a time.ParseDuration
+	// string (e.g. "5s"). Defaults to 10s.
+	Timeout string `yaml:"timeout"`
+
+	// Retry controls retries on network errors and 5xx/429 responses.
+	Retry RetryConfig `yaml:"retry"`
+
+	// Secret, if set, signs generic-json payloads with HMAC-SHA256 and
+	// sends the result as X-Panthor-Signature, so the receiver can verify
+	// the request actually came from this daemon. Ignored by the other
+	// notifier types.
+	Secret string `yaml:"secret"`
+
+	// TLS configures this webhook's outbound connections with a custom
+	// client certificate and/or private CA.
+	TLS *TLSConfig `yaml:"tls"`
+
+	// Filter restricts which changelogs this webhook is notified about,
+	// and when.
+	Filter filter.Config `yaml:"filter"`
+}
+
+// New builds the Notifier implementation matching cfg.Type. An empty Type
+// defaults to generic-json for backwards compatibility with plain webhooks.
+func New(cfg Config) (Notifier, error) {
+	switch cfg.Type {
+	case TypeDiscord:
+		return NewDiscord(cfg)
+	case TypeSlack:
+		return NewSlack(cfg)
+	case TypeMatrix:
+		return NewMatrix(cfg)
+	case TypeGenericJSON, "":
+		return NewGeneric(cfg)
+	default:
+		return nil, fmt.Errorf("unknown notifier type %q", cfg.Type)
+	}
+}
+
+// joinLines renders a list of change entries as a newline separated string,
+// falling back to a placeholder when the list is empty.
+func joinLines(lines []string) string {
+	if len(lines) == 0 {
+		return "-"
+	}
+
+	out := ""
+	for i, line := range lines {
+		if i > 0 {
+			out += "\n"
+		}
+		out += "- " + line
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+)
+
+// RetryConfig is the `retry:` section of a webhook Config.
+type RetryConfig struct {
+	MaxAttempts    int    `yaml:"max_attempts"`
+	InitialBackoff string `yaml:"initial_backoff"`
+	MaxBackoff     string `yaml:"max_backoff"`
+	Jitter         bool   `yaml:"jitter"`
+}
+
+// RetryPolicy is the resolved, ready-to-use form of a RetryConfig.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Jitter         bool
+}
+
+const (
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// Policy resolves c into a RetryPolicy, filling in defaults for anything
+// left unset.
+func (c RetryConfig) Policy() RetryPolicy {
+	policy := RetryPolicy{
+		MaxAttempts:    c.MaxAttempts,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+		Jitter:         c.Jitter,
+	}
+
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = defaultMaxAttempts
+	}
+
+	if c.InitialBackoff != "" {
+		if d, err := time.ParseDuration(c.InitialBackoff); err == nil {
+			policy.InitialBackoff = d
+		} else {
+			slog.Warn("invalid retry.initial_backoff, using default", "value", c.InitialBackoff, "error", err)
+		}
+	}
+
+	if c.MaxBackoff != "" {
+		if d, err := time.ParseDuration(c.MaxBackoff); err == nil {
+			policy.MaxBackoff = d
+		} else {
+			slog.Warn("invalid retry.max_backoff, using default", "value", c.MaxBackoff, "error", err)
+		}
+	}
+
+	return policy
+}
+
+// SendWithRetry calls n.Send, retrying according to policy when the
+// failure is a *DeliveryError reporting a network error or a 5xx/429
+// response. A Retry-After hint on the error takes precedence over the
+// policy's own backoff. Non-retryable errors (e.g. a 4xx other than 429)
+// are returned immediately.
+func SendWithRetry(ctx context.Context, n Notifier, c changelog.Changelog, policy RetryPolicy) error {
+	backoff := policy.InitialBackoff
+	var lastErr error
+
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		err := n.Send(ctx, c)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		var deliveryErr *DeliveryError
+		if !errors.As(err, &deliveryErr) || !deliveryErr.Temporary() {
+			return err
+		}
+
+		if attempt == policy.MaxAttempts {
+			break
+		}
+
+		wait := backoff
+		if deliveryErr.RetryAfter > 0 {
+			wait = deliveryErr.RetryAfter
+		}
+		if policy.Jitter {
+			wait += time.Duration(rand.Int63n(int64(wait)/2 + 1))
+		}
+
+		slog.Warn("webhook delivery failed, retrying", "attempt", attempt, "max_attempts", policy.MaxAttempts, "wait", wait, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+		if backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+
+	return lastErr
+}
@@ -0,0 +1,96 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+)
+
+// countingNotifier fails with err the first failUntil calls, then succeeds.
+type countingNotifier struct {
+	err       error
+	failUntil int
+	calls     int
+}
+
+func (n *countingNotifier) Send(ctx context.Context, c changelog.Changelog) error {
+	n.calls++
+	if n.calls <= n.failUntil {
+		return n.err
+	}
+	return nil
+}
+
+func TestSendWithRetryRetriesTemporaryErrors(t *testing.T) {
+	n := &countingNotifier{err: &DeliveryError{StatusCode: 503}, failUntil: 2}
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	if err := SendWithRetry(context.Background(), n, changelog.Changelog{}, policy); err != nil {
+		t.Fatalf("SendWithRetry returned an error after the notifier recovered: %v", err)
+	}
+	if n.calls != 3 {
+		t.Errorf("expected 3 calls, got %d", n.calls)
+	}
+}
+
+func TestSendWithRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	n := &countingNotifier{err: &DeliveryError{StatusCode: 503}, failUntil: 99}
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	err := SendWithRetry(context.Background(), n, changelog.Changelog{}, policy)
+	if err == nil {
+		t.Fatal("expected SendWithRetry to return an error once attempts are exhausted")
+	}
+	if n.calls != 3 {
+		t.Errorf("expected 3 calls, got %d", n.calls)
+	}
+}
+
+func TestSendWithRetryDoesNotRetryNonTemporaryErrors(t *testing.T) {
+	n := &countingNotifier{err: &DeliveryError{StatusCode: 400}, failUntil: 99}
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	if err := SendWithRetry(context.Background(), n, changelog.Changelog{}, policy); err == nil {
+		t.Fatal("expected SendWithRetry to return an error")
+	}
+	if n.calls != 1 {
+		t.Errorf("expected a 4xx error to not be retried, got %d calls", n.calls)
+	}
+}
+
+func TestSendWithRetryDoesNotRetryPlainErrors(t *testing.T) {
+	n := &countingNotifier{err: errors.New("boom"), failUntil: 99}
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	if err := SendWithRetry(context.Background(), n, changelog.Changelog{}, policy); err == nil {
+		t.Fatal("expected SendWithRetry to return an error")
+	}
+	if n.calls != 1 {
+		t.Errorf("expected a non-DeliveryError to not be retried, got %d calls", n.calls)
+	}
+}
+
+func TestRetryConfigPolicyDefaults(t *testing.T) {
+	policy := RetryConfig{}.Policy()
+
+	if policy.MaxAttempts != defaultMaxAttempts {
+		t.Errorf("expected default MaxAttempts %d, got %d", defaultMaxAttempts, policy.MaxAttempts)
+	}
+	if policy.InitialBackoff != defaultInitialBackoff {
+		t.Errorf("expected default InitialBackoff %v, got %v", defaultInitialBackoff, policy.InitialBackoff)
+	}
+	if policy.MaxBackoff != defaultMaxBackoff {
+		t.Errorf("expected default MaxBackoff %v, got %v", defaultMaxBackoff, policy.MaxBackoff)
+	}
+}
+
+func TestRetryConfigPolicyInvalidDurationFallsBackToDefault(t *testing.T) {
+	policy := RetryConfig{InitialBackoff: "not-a-duration"}.Policy()
+
+	if policy.InitialBackoff != defaultInitialBackoff {
+		t.Errorf("expected invalid initial_backoff to fall back to default, got %v", policy.InitialBackoff)
+	}
+}
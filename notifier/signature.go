@@ -0,0 +1,52 @@
+package notifier
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// sign computes the hex-encoded HMAC-SHA256 of body using secret, matching
+// the de-facto GitHub webhook signing convention.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether signature - as received in the
+// X-Panthor-Signature header, including its "sha256=" prefix - matches the
+// HMAC-SHA256 of body computed with secret. A receiver of a generic-json
+// webhook should call this before trusting the payload.
+func VerifySignature(secret string, body []byte, signature string) bool {
+	const prefix = "sha256="
+
+	if !strings.HasPrefix(signature, prefix) {
+		return false
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, prefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// newDeliveryID generates a random UUID-like identifier for the
+// X-Panthor-Delivery header.
+func newDeliveryID() string {
+	b := make([]byte, 16)
+	rand.Read(b) //nolint:errcheck // crypto/rand.Read never fails on supported platforms
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,41 @@
+package notifier
+
+import "testing"
+
+func TestVerifySignatureRoundTrip(t *testing.T) {
+	body := []byte(`{"version":"1.2.3"}`)
+	secret := "s3cr3t"
+
+	signature := "sha256=" + sign(secret, body)
+
+	if !VerifySignature(secret, body, signature) {
+		t.Error("VerifySignature rejected a signature it just produced")
+	}
+}
+
+func TestVerifySignatureRejectsWrongSecret(t *testing.T) {
+	body := []byte(`{"version":"1.2.3"}`)
+	signature := "sha256=" + sign("s3cr3t", body)
+
+	if VerifySignature("wrong-secret", body, signature) {
+		t.Error("VerifySignature accepted a signature computed with a different secret")
+	}
+}
+
+func TestVerifySignatureRejectsTamperedBody(t *testing.T) {
+	secret := "s3cr3t"
+	signature := "sha256=" + sign(secret, []byte(`{"version":"1.2.3"}`))
+
+	if VerifySignature(secret, []byte(`{"version":"1.2.4"}`), signature) {
+		t.Error("VerifySignature accepted a signature for a different body")
+	}
+}
+
+func TestVerifySignatureRejectsMissingPrefix(t *testing.T) {
+	body := []byte(`{"version":"1.2.3"}`)
+	secret := "s3cr3t"
+
+	if VerifySignature(secret, body, sign(secret, body)) {
+		t.Error("VerifySignature accepted a signature without the sha256= prefix")
+	}
+}
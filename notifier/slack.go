@@ -0,0 +1,91 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+)
+
+const defaultSlackTemplate = `A new version *{{.Version}}* is available!`
+
+// Slack delivers changelogs as Slack Block Kit messages via an incoming
+// webhook URL.
+type Slack struct {
+	url      string
+	template string
+	client   *http.Client
+}
+
+// NewSlack builds a Slack notifier from cfg.
+func NewSlack(cfg Config) (*Slack, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("slack notifier requires a url")
+	}
+
+	tlsConfig, err := buildTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Slack{url: cfg.URL, template: cfg.Template, client: newHTTPClient(parseTimeout(cfg.Timeout), tlsConfig)}, nil
+}
+
+type slackPayload struct {
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type   string      `json:"type"`
+	Text   *slackText  `json:"text,omitempty"`
+	Fields []slackText `json:"fields,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Send posts a Slack message describing c to the webhook URL.
+func (s *Slack) Send(ctx context.Context, c changelog.Changelog) error {
+	text, err := renderTemplate(s.template, defaultSlackTemplate, c)
+	if err != nil {
+		return err
+	}
+
+	blocks := []slackBlock{
+		{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: text},
+		},
+		{
+			Type: "section",
+			Fields: []slackText{
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Size:*\n%s", c.Size)},
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Released At:*\n%s", c.ReleaseAt)},
+			},
+		},
+	}
+
+	if len(c.ChangeMission) > 0 {
+		blocks = append(blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: fmt.Sprintf("*Missions:*\n%s", joinLines(c.ChangeMission))}})
+	}
+	if len(c.ChangeMap) > 0 {
+		blocks = append(blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: fmt.Sprintf("*Maps:*\n%s", joinLines(c.ChangeMap))}})
+	}
+	if len(c.ChangeMod) > 0 {
+		blocks = append(blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: fmt.Sprintf("*Mods:*\n%s", joinLines(c.ChangeMod))}})
+	}
+	if c.Note != "" {
+		blocks = append(blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: fmt.Sprintf("*Note:*\n%s", c.Note)}})
+	}
+
+	body, err := json.Marshal(slackPayload{Blocks: blocks})
+	if err != nil {
+		return fmt.Errorf("error marshaling slack payload: %w", err)
+	}
+
+	return postJSON(ctx, s.client, s.url, body, nil)
+}
@@ -0,0 +1,37 @@
+package notifier
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+)
+
+// renderTemplate executes the template at path against c and returns the
+// rendered output. If path is empty, fallback is used instead, which lets
+// every provider ship a sensible default while still allowing operators to
+// point `template:` at their own file.
+func renderTemplate(path, fallback string, c changelog.Changelog) (string, error) {
+	tmplSource := fallback
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("error reading template file: %w", err)
+		}
+		tmplSource = string(data)
+	}
+
+	tmpl, err := template.New("notification").Parse(tmplSource)
+	if err != nil {
+		return "", fmt.Errorf("error parsing template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, c); err != nil {
+		return "", fmt.Errorf("error executing template: %w", err)
+	}
+
+	return buf.String(), nil
+}
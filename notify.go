@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/tklein1801/panthor-update-notification/changelog"
+	"github.com/tklein1801/panthor-update-notification/deadletter"
+	"github.com/tklein1801/panthor-update-notification/filter"
+	"github.com/tklein1801/panthor-update-notification/health"
+	"github.com/tklein1801/panthor-update-notification/metrics"
+	"github.com/tklein1801/panthor-update-notification/notifier"
+	"github.com/tklein1801/panthor-update-notification/store"
+)
+
+// notifyClaimTTL bounds how long a version's delivery claim (see
+// store.Store.Claim) is held: long enough to cover one notify pass across
+// every webhook, short enough that a later tick can claim it again if this
+// one crashed mid-delivery or deferred some webhooks to quiet hours.
+const notifyClaimTTL = 5 * time.Minute
+
+// checkForUpdate fetches the latest changelog and, if its version differs
+// from the one recorded in st, notifies every configured webhook and
+// records the new version and delivery history. Running this both on
+// startup and on every cron tick means a version that was released while
+// the daemon was down still gets notified on the next check, instead of
+// being silently adopted as the new baseline.
+//
+// The global version pointer only gates *new* deliveries: a webhook that was
+// deferred by quiet hours is tracked separately via filter.PendingVersion, so
+// it keeps being retried on later ticks even once the pointer has moved on
+// and latest.Version == savedVersion for everyone else.
+func checkForUpdate(config *Config, st store.Store, status *health.Status, queue deadletter.Queue) error {
+	ctx := context.Background()
+	start := time.Now()
+
+	changelogs, err := GetChangelogs()
+
+	status.RecordPoll(err == nil)
+	metrics.PollDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.PollTotal.WithLabelValues("error").Inc()
+		return fmt.Errorf("failed to get changelogs: %w", err)
+	}
+	metrics.PollTotal.WithLabelValues("success").Inc()
+
+	slog.Debug("fetched changelogs", "count", len(*changelogs), "duration_ms", time.Since(start).Milliseconds())
+
+	if len(*changelogs) == 0 {
+		return fmt.Errorf("no changelogs found")
+	}
+
+	latest := (*changelogs)[0]
+	metrics.SetCurrentVersion(latest.Version)
+
+	savedVersion, err := st.Get(ctx, store.VersionKey)
+	if err != nil {
+		return fmt.Errorf("failed to get saved version: %w", err)
+	}
+	isNewVersion := latest.Version != savedVersion
+
+	pending, err := pendingWebhooks(ctx, st, config, latest.Version)
+	if err != nil {
+		return fmt.Errorf("failed to check pending deliveries: %w", err)
+	}
+
+	if !isNewVersion && len(pending) == 0 {
+		slog.Info("version is unchanged", "version", latest.Version)
+		return nil
+	}
+
+	// Claim this version before notifying, so that when several daemon
+	// instances share a Store (the Redis backend's reason for existing),
+	// only one of them actually delivers it. A later tick - on this
+	// instance or another - can claim it again once the TTL expires, which
+	// is what lets a quiet-hours-deferred webhook still get retried.
+	claimed, err := st.Claim(ctx, "notify:"+latest.Version, notifyClaimTTL)
+	if err != nil {
+		return fmt.Errorf("failed to claim delivery for version %s: %w", latest.Version, err)
+	}
+	if !claimed {
+		slog.Info("another instance already claimed this version, skipping", "version", latest.Version)
+		return nil
+	}
+
+	if isNewVersion {
+		slog.Info("new version available", "version", latest.Version, "changelog_id", latest.ID)
+	} else {
+		slog.Info("retrying deferred webhooks", "version", latest.Version, "webhooks", len(pending))
+	}
+
+	deliveries := Notify(config, latest, queue, st, isNewVersion, pending)
+
+	if isNewVersion {
+		if err := st.Set(ctx, store.VersionKey, latest.Version); err != nil {
+			return fmt.Errorf("failed to save version: %w", err)
+		}
+	}
+
+	entry := store.Entry{
+		ID:         latest.ID,
+		Version:    latest.Version,
+		ReleasedAt: latest.ReleaseAt,
+		NotifiedAt: time.Now().UTC().Format(time.RFC3339),
+		Deliveries: deliveries,
+	}
+	if err := st.AppendHistory(ctx, entry); err != nil {
+		return fmt.Errorf("failed to append history: %w", err)
+	}
+
+	return nil
+}
+
+// pendingWebhooks returns the set of webhook URLs that were previously
+// deferred on version and so must be retried on this tick even if the
+// global version pointer is unchanged.
+func pendingWebhooks(ctx context.Context, st store.Store, config *Config, version string) (map[string]bool, error) {
+	pending := make(map[string]bool)
+	for _, webhookConfig := range config.Notification.Webhooks {
+		deferredVersion, err := filter.PendingVersion(ctx, st, webhookConfig.URL)
+		if err != nil {
+			return nil, err
+		}
+		if deferredVersion == version {
+			pending[webhookConfig.URL] = true
+		}
+	}
+	return pending, nil
+}
+
+// Notify builds the configured Notifier for every webhook due a delivery
+// and sends the changelog to each of them with retries, logging and
+// continuing past individual delivery failures so one broken webhook can't
+// prevent the others from being notified. On a new version every webhook is
+// considered; otherwise only the ones in pending (previously deferred by
+// quiet hours on this exact version) are retried. Webhooks whose filter or
+// debounce window reject the changelog are skipped entirely; one still in
+// its quiet-hours window is deferred again via filter.MarkPending so a later
+// tick retries it. A delivery that exhausts its retry policy is persisted to
+// queue for later redelivery. Notify returns the delivery status ("ok",
+// "skipped: ...", or "error: ...") per webhook URL for recording in the
+// store's history.
+func Notify(config *Config, latest changelog.Changelog, queue deadletter.Queue, st store.Store, isNewVersion bool, pending map[string]bool) map[string]string {
+	deliveries := make(map[string]string, len(config.Notification.Webhooks))
+	now := time.Now()
+
+	for _, webhookConfig := range config.Notification.Webhooks {
+		if !isNewVersion && !pending[webhookConfig.URL] {
+			continue
+		}
+
+		skipReason, err := shouldSkip(context.Background(), st, webhookConfig, latest, now)
+		if err != nil {
+			slog.Error("failed to evaluate filter", "webhook_url", webhookConfig.URL, "error", err)
+			deliveries[webhookConfig.URL] = fmt.Sprintf("error: %v", err)
+			continue
+		}
+		if skipReason == "quiet_hours" {
+			slog.Info("deferring webhook", "webhook_url", webhookConfig.URL, "version", latest.Version, "reason", skipReason)
+			deliveries[webhookConfig.URL] = "skipped: " + skipReason
+			if err := filter.MarkPending(context.Background(), st, webhookConfig.URL, latest); err != nil {
+				slog.Warn("failed to record pending delivery", "webhook_url", webhookConfig.URL, "error", err)
+			}
+			continue
+		}
+		if err := filter.ClearPending(context.Background(), st, webhookConfig.URL); err != nil {
+			slog.Warn("failed to clear pending delivery", "webhook_url", webhookConfig.URL, "error", err)
+		}
+		if skipReason != "" {
+			slog.Info("skipping webhook", "webhook_url", webhookConfig.URL, "version", latest.Version, "reason", skipReason)
+			deliveries[webhookConfig.URL] = "skipped: " + skipReason
+			continue
+		}
+
+		start := time.Now()
+
+		n, err := notifier.New(webhookConfig)
+		if err != nil {
+			slog.Error("failed to build notifier", "webhook_url", webhookConfig.URL, "error", err)
+			deliveries[webhookConfig.URL] = fmt.Sprintf("error: %v", err)
+			metrics.WebhookDeliveryTotal.WithLabelValues(webhookConfig.URL, "error").Inc()
+			continue
+		}
+
+		err = notifier.SendWithRetry(context.Background(), n, latest, webhookConfig.Retry.Policy())
+		metrics.WebhookDuration.WithLabelValues(webhookConfig.URL).Observe(time.Since(start).Seconds())
+		if err != nil {
+			slog.Error("failed to trigger webhook", "webhook_url", webhookConfig.URL, "version", latest.Version, "duration_ms", time.Since(start).Milliseconds(), "error", err)
+			deliveries[webhookConfig.URL] = fmt.Sprintf("error: %v", err)
+			metrics.WebhookDeliveryTotal.WithLabelValues(webhookConfig.URL, "error").Inc()
+
+			enqueueDeadLetter(queue, webhookConfig, latest, err)
+			continue
+		}
+
+		slog.Info("webhook delivered", "webhook_url", webhookConfig.URL, "version", latest.Version, "duration_ms", time.Since(start).Milliseconds())
+		deliveries[webhookConfig.URL] = "ok"
+		metrics.WebhookDeliveryTotal.WithLabelValues(webhookConfig.URL, "ok").Inc()
+
+		if err := filter.MarkNotified(context.Background(), st, webhookConfig.URL, latest, now); err != nil {
+			slog.Warn("failed to record debounce state", "webhook_url", webhookConfig.URL, "error", err)
+		}
+	}
+
+	return deliveries
+}
+
+// shouldSkip reports whether webhookConfig's filter, quiet-hours window, or
+// debounce window mean latest should not be sent to it right now. An empty
+// string with a nil error means the delivery should proceed.
+func shouldSkip(ctx context.Context, st store.Store, webhookConfig notifier.Config, latest changelog.Changelog, now time.Time) (string, error) {
+	match, err := filter.Match(webhookConfig.Filter, latest)
+	if err != nil {
+		return "", err
+	}
+	if !match {
+		return "filter", nil
+	}
+
+	inQuietHours, err := filter.InQuietHours(webhookConfig.Filter.QuietHours, now)
+	if err != nil {
+		return "", err
+	}
+	if inQuietHours {
+		return "quiet_hours", nil
+	}
+
+	debounced, err := filter.Debounced(ctx, st, webhookConfig.URL, webhookConfig.Filter, latest, now)
+	if err != nil {
+		return "", err
+	}
+	if debounced {
+		return "debounced", nil
+	}
+
+	return "", nil
+}
+
+// enqueueDeadLetter persists a webhook delivery that exhausted its retry
+// policy so it can be re-driven later via `panthor-notify redeliver`.
+func enqueueDeadLetter(queue deadletter.Queue, webhookConfig notifier.Config, latest changelog.Changelog, deliveryErr error) {
+	item := deadletter.Item{
+		ID:         fmt.Sprintf("%d-%d", latest.ID, time.Now().UnixNano()),
+		WebhookURL: webhookConfig.URL,
+		Changelog:  latest,
+		Error:      deliveryErr.Error(),
+		FailedAt:   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if err := queue.Enqueue(context.Background(), item); err != nil {
+		slog.Error("failed to persist dead-letter item", "webhook_url", webhookConfig.URL, "error", errors.Join(deliveryErr, err))
+	}
+}
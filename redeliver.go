@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/tklein1801/panthor-update-notification/deadletter"
+	"github.com/tklein1801/panthor-update-notification/notifier"
+)
+
+// runRedeliver implements `panthor-notify redeliver`: it loads every item
+// currently sitting in the dead-letter queue and attempts to deliver it
+// again, removing it from the queue on success and leaving it in place on
+// failure so it can be retried again later.
+func runRedeliver() error {
+	config, err := LoadConfig("config.yml")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	queue, err := deadletter.NewDirQueue(deadLetterDir(config.DeadLetter))
+	if err != nil {
+		return fmt.Errorf("failed to initialize dead-letter queue: %w", err)
+	}
+
+	items, err := queue.List(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to list dead-letter items: %w", err)
+	}
+
+	if len(items) == 0 {
+		slog.Info("no dead-lettered deliveries to redeliver")
+		return nil
+	}
+
+	webhooksByURL := make(map[string]notifier.Config, len(config.Notification.Webhooks))
+	for _, webhookConfig := range config.Notification.Webhooks {
+		webhooksByURL[webhookConfig.URL] = webhookConfig
+	}
+
+	var failed int
+	for _, item := range items {
+		webhookConfig, ok := webhooksByURL[item.WebhookURL]
+		if !ok {
+			slog.Error("dead-letter item references a webhook no longer in config.yml, skipping", "id", item.ID, "webhook_url", item.WebhookURL)
+			failed++
+			continue
+		}
+
+		n, err := notifier.New(webhookConfig)
+		if err != nil {
+			slog.Error("failed to build notifier for dead-letter item", "id", item.ID, "webhook_url", item.WebhookURL, "error", err)
+			failed++
+			continue
+		}
+
+		if err := n.Send(context.Background(), item.Changelog); err != nil {
+			slog.Error("redelivery failed", "id", item.ID, "webhook_url", item.WebhookURL, "error", err)
+			failed++
+			continue
+		}
+
+		if err := queue.Remove(context.Background(), item.ID); err != nil {
+			slog.Error("redelivered but failed to remove dead-letter item", "id", item.ID, "error", err)
+			failed++
+			continue
+		}
+
+		slog.Info("redelivered", "id", item.ID, "webhook_url", item.WebhookURL, "version", item.Changelog.Version)
+	}
+
+	slog.Info("redeliver finished", "total", len(items), "failed", failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d deliveries still failed", failed, len(items))
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+// Package server exposes the daemon's health and metrics endpoints for
+// container orchestrators to probe.
+package server
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/tklein1801/panthor-update-notification/health"
+)
+
+// New builds the HTTP handler serving /livez, /readyz, and /metrics.
+func New(status *health.Status) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/livez", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if err := status.Ready(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
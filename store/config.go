@@ -0,0 +1,48 @@
+package store
+
+import "fmt"
+
+// Backend selects which Store implementation a Config builds.
+type Backend string
+
+const (
+	BackendFile   Backend = "file"
+	BackendSQLite Backend = "sqlite"
+	BackendRedis  Backend = "redis"
+)
+
+// Config describes the `store:` section of config.yml.
+type Config struct {
+	Backend Backend `yaml:"backend"`
+	// Path is the version.yml path for the file backend, or the database
+	// file path for the sqlite backend.
+	Path  string `yaml:"path"`
+	Redis struct {
+		Addr     string `yaml:"addr"`
+		Password string `yaml:"password"`
+		DB       int    `yaml:"db"`
+	} `yaml:"redis"`
+}
+
+// New builds the Store implementation matching cfg.Backend. An empty
+// Backend defaults to file, matching the original version.yml behavior.
+func New(cfg Config) (Store, error) {
+	switch cfg.Backend {
+	case BackendFile, "":
+		path := cfg.Path
+		if path == "" {
+			path = "version.yml"
+		}
+		return NewFileStore(path), nil
+	case BackendSQLite:
+		path := cfg.Path
+		if path == "" {
+			path = "panthor.db"
+		}
+		return NewSQLiteStore(path)
+	case BackendRedis:
+		return NewRedisStore(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB), nil
+	default:
+		return nil, fmt.Errorf("unknown store backend %q", cfg.Backend)
+	}
+}
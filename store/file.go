@@ -0,0 +1,150 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileStore persists values and history to a single YAML file. It replaces
+// the original, version-only version.yml handling with a new layout: a
+// `values:` map next to a `history:` list. A file in the old layout, with a
+// top-level `version:` key, is read as holding no saved version.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore builds a FileStore backed by the file at path.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+type fileData struct {
+	Values  map[string]string `yaml:"values"`
+	History []Entry           `yaml:"history"`
+}
+
+func (f *FileStore) load() (*fileData, error) {
+	data := &fileData{Values: map[string]string{}}
+
+	raw, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return data, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading the file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(raw, data); err != nil {
+		return nil, fmt.Errorf("error parsing the YAML file: %w", err)
+	}
+
+	if data.Values == nil {
+		data.Values = map[string]string{}
+	}
+
+	return data, nil
+}
+
+func (f *FileStore) save(data *fileData) error {
+	raw, err := yaml.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("error marshaling data to YAML: %w", err)
+	}
+
+	if err := os.WriteFile(f.path, raw, 0644); err != nil {
+		return fmt.Errorf("error writing to file: %w", err)
+	}
+
+	return nil
+}
+
+func (f *FileStore) Get(ctx context.Context, key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := f.load()
+	if err != nil {
+		return "", err
+	}
+
+	return data.Values[key], nil
+}
+
+func (f *FileStore) Set(ctx context.Context, key, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	data.Values[key] = value
+
+	return f.save(data)
+}
+
+func (f *FileStore) AppendHistory(ctx context.Context, entry Entry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	data.History = append(data.History, entry)
+
+	return f.save(data)
+}
+
+func (f *FileStore) History(ctx context.Context, n int) ([]Entry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, len(data.History))
+	for i, entry := range data.History {
+		entries[len(data.History)-1-i] = entry
+	}
+
+	if n >= 0 && n < len(entries) {
+		entries = entries[:n]
+	}
+
+	return entries, nil
+}
+
+func (f *FileStore) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := f.load()
+	if err != nil {
+		return false, err
+	}
+
+	if expiresAt, ok := data.Values[claimKey(key)]; ok {
+		if expiry, err := time.Parse(time.RFC3339Nano, expiresAt); err == nil && time.Now().Before(expiry) {
+			return false, nil
+		}
+	}
+
+	data.Values[claimKey(key)] = time.Now().Add(ttl).Format(time.RFC3339Nano)
+
+	return true, f.save(data)
+}
+
+func (f *FileStore) Close() error {
+	return nil
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileStoreClaimPreventsDoubleClaim(t *testing.T) {
+	st := NewFileStore(filepath.Join(t.TempDir(), "state.yml"))
+	ctx := context.Background()
+
+	claimed, err := st.Claim(ctx, "v1.2.3", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !claimed {
+		t.Fatal("expected the first claim to succeed")
+	}
+
+	claimed, err = st.Claim(ctx, "v1.2.3", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claimed {
+		t.Error("expected a second claim on the same key to fail while the first is still valid")
+	}
+}
+
+func TestFileStoreClaimCanBeReacquiredAfterExpiry(t *testing.T) {
+	st := NewFileStore(filepath.Join(t.TempDir(), "state.yml"))
+	ctx := context.Background()
+
+	claimed, err := st.Claim(ctx, "v1.2.3", -time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !claimed {
+		t.Fatal("expected the first claim to succeed")
+	}
+
+	claimed, err = st.Claim(ctx, "v1.2.3", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !claimed {
+		t.Error("expected a claim to be reacquirable once the previous one expired")
+	}
+}
@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const historyListKey = "history"
+
+// RedisStore persists values and history in Redis, so multiple daemon
+// instances can share state and avoid double-notifying.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore builds a RedisStore connected to addr/db, authenticating
+// with password if it is non-empty.
+func NewRedisStore(addr, password string, db int) *RedisStore {
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+
+	return &RedisStore{client: client, prefix: "panthor:"}
+}
+
+func (r *RedisStore) Get(ctx context.Context, key string) (string, error) {
+	value, err := r.client.Get(ctx, r.prefix+key).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading key %q: %w", key, err)
+	}
+
+	return value, nil
+}
+
+func (r *RedisStore) Set(ctx context.Context, key, value string) error {
+	if err := r.client.Set(ctx, r.prefix+key, value, 0).Err(); err != nil {
+		return fmt.Errorf("error writing key %q: %w", key, err)
+	}
+
+	return nil
+}
+
+func (r *RedisStore) AppendHistory(ctx context.Context, entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error marshaling entry: %w", err)
+	}
+
+	if err := r.client.LPush(ctx, r.prefix+historyListKey, data).Err(); err != nil {
+		return fmt.Errorf("error appending history: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RedisStore) History(ctx context.Context, n int) ([]Entry, error) {
+	// n < 0 means "all", matching FileStore and SQLiteStore (where it's a
+	// slice bound and a LIMIT -1 respectively). LRange has no "to the end"
+	// sentinel other than -1, so that case is handled separately from n == 0.
+	if n == 0 {
+		return []Entry{}, nil
+	}
+
+	stop := int64(-1)
+	if n > 0 {
+		stop = int64(n - 1)
+	}
+
+	raw, err := r.client.LRange(ctx, r.prefix+historyListKey, 0, stop).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error reading history: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(raw))
+	for _, item := range raw {
+		var entry Entry
+		if err := json.Unmarshal([]byte(item), &entry); err != nil {
+			return nil, fmt.Errorf("error unmarshaling entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Claim uses SETNX (via SetNX, atomic in Redis) so that when several daemon
+// instances share this backend, only one of them wins the claim for a given
+// key.
+func (r *RedisStore) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ok, err := r.client.SetNX(ctx, r.prefix+claimKey(key), "1", ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("error claiming key %q: %w", key, err)
+	}
+
+	return ok, nil
+}
+
+func (r *RedisStore) Close() error {
+	return r.client.Close()
+}
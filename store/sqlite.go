@@ -0,0 +1,148 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore persists values and history in a SQLite database, so state
+// survives container restarts without relying on the working directory
+// being writable across deploys the way the file backend does.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating if necessary) the SQLite database at path
+// and ensures its schema exists.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening sqlite database: %w", err)
+	}
+
+	schema := `
+	CREATE TABLE IF NOT EXISTS kv (
+		key   TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS history (
+		id           INTEGER PRIMARY KEY AUTOINCREMENT,
+		changelog_id INTEGER NOT NULL,
+		version      TEXT NOT NULL,
+		released_at  TEXT,
+		notified_at  TEXT,
+		deliveries   TEXT
+	);`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error creating sqlite schema: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Get(ctx context.Context, key string) (string, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM kv WHERE key = ?`, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading key %q: %w", key, err)
+	}
+
+	return value, nil
+}
+
+func (s *SQLiteStore) Set(ctx context.Context, key, value string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO kv (key, value) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value`, key, value)
+	if err != nil {
+		return fmt.Errorf("error writing key %q: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteStore) AppendHistory(ctx context.Context, entry Entry) error {
+	deliveries, err := json.Marshal(entry.Deliveries)
+	if err != nil {
+		return fmt.Errorf("error marshaling deliveries: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO history (changelog_id, version, released_at, notified_at, deliveries)
+		VALUES (?, ?, ?, ?, ?)`, entry.ID, entry.Version, entry.ReleasedAt, entry.NotifiedAt, string(deliveries))
+	if err != nil {
+		return fmt.Errorf("error appending history: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteStore) History(ctx context.Context, n int) ([]Entry, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT changelog_id, version, released_at, notified_at, deliveries
+		FROM history ORDER BY id DESC LIMIT ?`, n)
+	if err != nil {
+		return nil, fmt.Errorf("error querying history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []Entry
+	for rows.Next() {
+		var entry Entry
+		var deliveries string
+		if err := rows.Scan(&entry.ID, &entry.Version, &entry.ReleasedAt, &entry.NotifiedAt, &deliveries); err != nil {
+			return nil, fmt.Errorf("error scanning history row: %w", err)
+		}
+		if err := json.Unmarshal([]byte(deliveries), &entry.Deliveries); err != nil {
+			return nil, fmt.Errorf("error unmarshaling deliveries: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (s *SQLiteStore) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("error beginning claim transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var expiresAt string
+	err = tx.QueryRowContext(ctx, `SELECT value FROM kv WHERE key = ?`, claimKey(key)).Scan(&expiresAt)
+	if err != nil && err != sql.ErrNoRows {
+		return false, fmt.Errorf("error reading claim %q: %w", key, err)
+	}
+	if err == nil {
+		if expiry, parseErr := time.Parse(time.RFC3339Nano, expiresAt); parseErr == nil && time.Now().Before(expiry) {
+			return false, nil
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO kv (key, value) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value`, claimKey(key), time.Now().Add(ttl).Format(time.RFC3339Nano))
+	if err != nil {
+		return false, fmt.Errorf("error writing claim %q: %w", key, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("error committing claim transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
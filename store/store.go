@@ -0,0 +1,53 @@
+// Package store persists the last known changelog version together with a
+// history of every changelog the daemon has seen and notified about, so
+// that a restart doesn't lose track of what was already delivered.
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// VersionKey is the key under which the last notified version is stored.
+const VersionKey = "version"
+
+// claimKey namespaces a Claim key so it can't collide with a plain Get/Set
+// key in the same backend.
+func claimKey(key string) string {
+	return "claim:" + key
+}
+
+// Entry records a single changelog the daemon has observed, including the
+// delivery status of every webhook it was sent to.
+type Entry struct {
+	ID         int               `json:"id" yaml:"id"`
+	Version    string            `json:"version" yaml:"version"`
+	ReleasedAt string            `json:"released_at" yaml:"released_at"`
+	NotifiedAt string            `json:"notified_at" yaml:"notified_at"`
+	Deliveries map[string]string `json:"deliveries" yaml:"deliveries"` // webhook URL -> "ok", "skipped: ..." or "error: ..."
+}
+
+// Store abstracts how the daemon remembers what it has already notified
+// about. The redis backend can be shared by several daemon instances
+// without double-notifying; FileStore serializes access only within a
+// single process and does not lock its file against other processes.
+type Store interface {
+	// Get returns the value stored under key, or "" if it doesn't exist.
+	Get(ctx context.Context, key string) (string, error)
+	// Set stores value under key.
+	Set(ctx context.Context, key, value string) error
+	// AppendHistory records entry as the most recent history item.
+	AppendHistory(ctx context.Context, entry Entry) error
+	// History returns up to n of the most recently recorded entries, newest first.
+	History(ctx context.Context, n int) ([]Entry, error)
+	// Claim atomically reserves key for ttl, returning true if this call
+	// acquired it - meaning the caller should go ahead with the work the
+	// claim guards - or false if another caller already holds an unexpired
+	// claim on key, meaning the caller should skip that work. This is how
+	// multiple daemon instances sharing a Store (the reason the Redis
+	// backend exists) agree on a single instance to deliver a given
+	// changelog instead of every instance notifying independently.
+	Claim(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Close releases any resources held by the store.
+	Close() error
+}